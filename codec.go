@@ -0,0 +1,82 @@
+package fncache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec defines how cached values are serialized to and from bytes. Cache
+// layers that need to store values out-of-process (e.g. RedisCache) accept
+// a Codec so callers can pick a wire format that suits their deployment,
+// rather than being locked into one encoding.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// GobCodec encodes values using encoding/gob. FnReturns must be
+// gob-encodable. This is the default codec, matching the historical
+// behavior of RedisCache.
+type GobCodec struct{}
+
+func (GobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// JSONCodec encodes values using encoding/json, producing human-readable,
+// cross-language wire data at the cost of some size and speed.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// MsgpackCodec encodes values using github.com/vmihailenco/msgpack, a
+// compact binary format that is still readable by non-Go consumers.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Marshal(v any) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (MsgpackCodec) Unmarshal(data []byte, v any) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+// ProtoCodec encodes values using the protobuf wire format. It requires
+// the cached value to implement proto.Message; Marshal and Unmarshal
+// return an error otherwise.
+type ProtoCodec struct{}
+
+func (ProtoCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("fncache: ProtoCodec requires a proto.Message, got %T", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtoCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("fncache: ProtoCodec requires a proto.Message, got %T", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
@@ -0,0 +1,79 @@
+package fncache_test
+
+import (
+	"testing"
+
+	"github.com/NodePath81/fncache"
+)
+
+type codecTestStruct struct {
+	ID   int
+	Name string
+}
+
+func TestGobCodecRoundTrip(t *testing.T) {
+	codec := fncache.GobCodec{}
+	in := codecTestStruct{ID: 1, Name: "gob"}
+
+	data, err := codec.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var out codecTestStruct
+	if err := codec.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	codec := fncache.JSONCodec{}
+	in := codecTestStruct{ID: 2, Name: "json"}
+
+	data, err := codec.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var out codecTestStruct
+	if err := codec.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+}
+
+func TestMsgpackCodecRoundTrip(t *testing.T) {
+	codec := fncache.MsgpackCodec{}
+	in := codecTestStruct{ID: 3, Name: "msgpack"}
+
+	data, err := codec.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var out codecTestStruct
+	if err := codec.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+}
+
+func TestProtoCodecRequiresProtoMessage(t *testing.T) {
+	codec := fncache.ProtoCodec{}
+
+	if _, err := codec.Marshal(codecTestStruct{}); err == nil {
+		t.Error("Expected error marshaling a non-proto.Message value")
+	}
+
+	var out codecTestStruct
+	if err := codec.Unmarshal([]byte{}, &out); err == nil {
+		t.Error("Expected error unmarshaling into a non-proto.Message value")
+	}
+}
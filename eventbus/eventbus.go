@@ -0,0 +1,18 @@
+// Package eventbus lets CacheLayer implementations publish key invalidation
+// events so other nodes (or other cache tiers on the same node) can evict
+// their own copies when a key changes elsewhere.
+package eventbus
+
+import "context"
+
+// EventBus publishes and delivers key invalidation notifications.
+type EventBus interface {
+	// Publish announces that key has changed and any cached copies of it
+	// should be considered stale.
+	Publish(ctx context.Context, key string) error
+
+	// Subscribe registers handler to run for every key published by other
+	// nodes. Subscribe blocks until ctx is canceled or the underlying
+	// subscription is closed.
+	Subscribe(ctx context.Context, handler func(key string)) error
+}
@@ -0,0 +1,80 @@
+package eventbus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisEventBus implements EventBus using a Redis PUBSUB channel.
+type RedisEventBus struct {
+	client  *redis.Client
+	channel string
+	nodeID  string
+}
+
+// NewRedisEventBus creates a RedisEventBus that publishes and subscribes on
+// the given channel. Each instance is tagged with a random node ID so that
+// Subscribe can ignore events published by this same instance.
+func NewRedisEventBus(client *redis.Client, channel string) *RedisEventBus {
+	return &RedisEventBus{
+		client:  client,
+		channel: channel,
+		nodeID:  newNodeID(),
+	}
+}
+
+// message is the wire format published to the Redis channel.
+type message struct {
+	NodeID string `json:"node_id"`
+	Key    string `json:"key"`
+}
+
+func newNodeID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// Publish announces key on the Redis channel, tagged with this bus's node
+// ID so this same instance's Subscribe can filter it out; other instances
+// still receive it.
+func (b *RedisEventBus) Publish(ctx context.Context, key string) error {
+	payload, err := json.Marshal(message{NodeID: b.nodeID, Key: key})
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Subscribe listens on the Redis channel and invokes handler for every key
+// published by other node IDs, until ctx is canceled.
+func (b *RedisEventBus) Subscribe(ctx context.Context, handler func(key string)) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			var m message
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+				continue
+			}
+			if m.NodeID == b.nodeID {
+				continue
+			}
+			handler(m.Key)
+		}
+	}
+}
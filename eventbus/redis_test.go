@@ -0,0 +1,92 @@
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NodePath81/fncache/eventbus"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisEventBus_PublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+
+	redisOpts := &goredis.Options{
+		Addr:     "localhost:6379",
+		Password: "",
+		DB:       0,
+	}
+
+	client := goredis.NewClient(redisOpts)
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skip("Skipping eventbus tests: Redis server not available")
+		return
+	}
+	defer client.Close()
+
+	publisher := eventbus.NewRedisEventBus(client, "fncache-test-events")
+	subscriber := eventbus.NewRedisEventBus(client, "fncache-test-events")
+
+	received := make(chan string, 1)
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		_ = subscriber.Subscribe(subCtx, func(key string) {
+			received <- key
+		})
+	}()
+
+	// Give the subscription a moment to establish before publishing.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, publisher.Publish(ctx, "some-key"))
+
+	select {
+	case key := <-received:
+		require.Equal(t, "some-key", key)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for published event")
+	}
+}
+
+func TestRedisEventBus_IgnoresOwnEvents(t *testing.T) {
+	ctx := context.Background()
+
+	redisOpts := &goredis.Options{
+		Addr:     "localhost:6379",
+		Password: "",
+		DB:       0,
+	}
+
+	client := goredis.NewClient(redisOpts)
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skip("Skipping eventbus tests: Redis server not available")
+		return
+	}
+	defer client.Close()
+
+	bus := eventbus.NewRedisEventBus(client, "fncache-test-self-events")
+
+	received := make(chan string, 1)
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		_ = bus.Subscribe(subCtx, func(key string) {
+			received <- key
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, bus.Publish(ctx, "self-key"))
+
+	select {
+	case key := <-received:
+		t.Fatalf("Expected own event to be filtered out, got %q", key)
+	case <-time.After(300 * time.Millisecond):
+		// No event received, as expected.
+	}
+}
@@ -2,16 +2,32 @@ package fncache
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
+// ErrNegativeCached is returned by FnCache.Get when params was recently
+// classified as "not found" by IsNegative and is still within NegativeTTL.
+var ErrNegativeCached = errors.New("fncache: negative cache hit")
+
 type FnGetType[FnParams comparable, FnReturns any] func(context.Context, FnParams) (FnReturns, error)
 type FnSetType[FnParams comparable, FnReturns any] func(context.Context, FnParams, FnReturns) error
 
+// FnGetMultiType fetches values for a batch of params in a single call,
+// returning only the entries it was able to resolve.
+type FnGetMultiType[FnParams comparable, FnReturns any] func(context.Context, []FnParams) (map[FnParams]FnReturns, error)
+
 // concurrent safe cache layer
 type CacheLayer[FnParams comparable, FnReturns any] interface {
 	Get(ctx context.Context, params FnParams) (FnReturns, error)
 	Set(ctx context.Context, params FnParams, value FnReturns) error
+	Delete(ctx context.Context, params FnParams) error
+	Exists(ctx context.Context, params FnParams) (bool, error)
+	GetMulti(ctx context.Context, params []FnParams) (map[FnParams]FnReturns, error)
+	SetMulti(ctx context.Context, values map[FnParams]FnReturns) error
 }
 
 type FnCache[FnParams comparable, FnReturns any] struct {
@@ -19,37 +35,299 @@ type FnCache[FnParams comparable, FnReturns any] struct {
 
 	cache CacheLayer[FnParams, FnReturns]
 
-	getFn FnGetType[FnParams, FnReturns]
-	setFn FnSetType[FnParams, FnReturns]
+	getFn      FnGetType[FnParams, FnReturns]
+	setFn      FnSetType[FnParams, FnReturns]
+	getMultiFn FnGetMultiType[FnParams, FnReturns]
+
+	group      singleflight.Group
+	refreshing sync.Map // FnParams -> struct{}, dedupes in-flight async refreshes
+	meta       sync.Map // FnParams -> entryMeta
+
+	clock   func() time.Time
+	keyFunc KeyFunc[FnParams]
 }
 
-func NewFnCache[FnParams comparable, FnReturns any](getFn FnGetType[FnParams, FnReturns], setFn FnSetType[FnParams, FnReturns], cacheLayer CacheLayer[FnParams, FnReturns], config CacheConfig) *FnCache[FnParams, FnReturns] {
-	return &FnCache[FnParams, FnReturns]{
+// entryMeta tracks bookkeeping that CacheLayer itself has no notion of:
+// when an entry was last populated, and whether it represents a cached
+// negative result.
+type entryMeta struct {
+	setAt    time.Time
+	negative bool
+}
+
+// Option configures an FnCache constructed via NewFnCache.
+type Option[FnParams comparable, FnReturns any] func(*FnCache[FnParams, FnReturns])
+
+// WithGetMultiFn sets a bulk fetch function that FnCache.GetMulti uses to
+// resolve cache misses in a single call, rather than calling getFn once per
+// miss. If not set, GetMulti falls back to calling getFn for each miss.
+func WithGetMultiFn[FnParams comparable, FnReturns any](fn FnGetMultiType[FnParams, FnReturns]) Option[FnParams, FnReturns] {
+	return func(c *FnCache[FnParams, FnReturns]) {
+		c.getMultiFn = fn
+	}
+}
+
+// WithClock overrides the time source FnCache uses to evaluate SoftTTL,
+// HardTTL, and NegativeTTL. Intended for tests; production callers should
+// leave it unset, which defaults to time.Now.
+func WithClock[FnParams comparable, FnReturns any](clock func() time.Time) Option[FnParams, FnReturns] {
+	return func(c *FnCache[FnParams, FnReturns]) {
+		c.clock = clock
+	}
+}
+
+// WithKeyFunc overrides how FnParams is turned into the singleflight group
+// key used to coalesce concurrent getFn calls (see CacheConfig.DisableCoalesce
+// and the stale-while-revalidate refresh in Get). The default,
+// KeyFuncJSONSHA256, is collision-resistant; callers coalescing only
+// gob-encodable params that must avoid a JSON dependency can switch to
+// KeyFuncGobSHA256.
+func WithKeyFunc[FnParams comparable, FnReturns any](fn KeyFunc[FnParams]) Option[FnParams, FnReturns] {
+	return func(c *FnCache[FnParams, FnReturns]) {
+		c.keyFunc = fn
+	}
+}
+
+func NewFnCache[FnParams comparable, FnReturns any](getFn FnGetType[FnParams, FnReturns], setFn FnSetType[FnParams, FnReturns], cacheLayer CacheLayer[FnParams, FnReturns], config CacheConfig, options ...Option[FnParams, FnReturns]) *FnCache[FnParams, FnReturns] {
+	c := &FnCache[FnParams, FnReturns]{
 		cache:       cacheLayer,
 		CacheConfig: config,
 		getFn:       getFn,
 		setFn:       setFn,
+		keyFunc:     KeyFuncJSONSHA256[FnParams],
 	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
 }
 
 type CacheConfig struct {
 	CacheDuration      time.Duration
 	CacheCheckInterval time.Duration
+
+	// DisableCoalesce turns off singleflight-style request coalescing in
+	// Get. By default (DisableCoalesce false), concurrent cache misses for
+	// the same params share a single getFn call instead of each invoking
+	// getFn independently. This is the opt-out equivalent of a
+	// Coalesce-bool-defaulting-to-true field: every other CacheConfig field
+	// is a plain value that is meaningful at its zero value, and a bool
+	// that must default to true without a pointer or an explicit
+	// "is this set" flag needs to be spelled as its own negation.
+	DisableCoalesce bool
+
+	// SoftTTL, if set, enables stale-while-revalidate: once an entry is
+	// older than SoftTTL (but still younger than HardTTL, if set), Get
+	// returns the stale value immediately and triggers an async getFn
+	// refresh in the background.
+	SoftTTL time.Duration
+
+	// HardTTL, if set, bounds how long a stale entry may still be served
+	// past SoftTTL. Once an entry is older than HardTTL, Get falls back to
+	// fetching synchronously, the same as a cache miss.
+	HardTTL time.Duration
+
+	// NegativeTTL, if set together with IsNegative, caches "not found"
+	// results: when getFn returns an error IsNegative classifies as a
+	// negative result, subsequent Get calls for the same params return
+	// ErrNegativeCached without calling getFn again until NegativeTTL
+	// elapses.
+	NegativeTTL time.Duration
+
+	// IsNegative classifies a getFn error as a cacheable negative result.
+	// If nil, negative caching is disabled regardless of NegativeTTL.
+	IsNegative func(error) bool
+}
+
+func (c *FnCache[FnParams, FnReturns]) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
 }
 
 func (c *FnCache[FnParams, FnReturns]) Get(ctx context.Context, params FnParams) (FnReturns, error) {
+	var zero FnReturns
+
+	if meta, ok := c.loadMeta(params); ok {
+		age := c.now().Sub(meta.setAt)
+
+		switch {
+		case meta.negative:
+			if c.NegativeTTL > 0 && age < c.NegativeTTL {
+				return zero, ErrNegativeCached
+			}
+			c.meta.Delete(params)
+		case c.HardTTL > 0 && age >= c.HardTTL:
+			// Past HardTTL, the CacheLayer's own (independently configured)
+			// TTL may not have expired this entry yet, so a plain cache.Get
+			// here could keep serving it forever. Force a synchronous
+			// refetch instead, the same as a genuine cache miss.
+			return c.forceRefetch(ctx, params)
+		case c.SoftTTL > 0 && age >= c.SoftTTL:
+			if value, err := c.cache.Get(ctx, params); err == nil {
+				c.refreshAsync(params)
+				return value, nil
+			}
+		case c.HardTTL <= 0 && c.SoftTTL <= 0 && c.CacheDuration > 0 && age >= c.CacheDuration:
+			// Neither SoftTTL nor HardTTL is in play, so nothing reads this
+			// meta entry's age.
+			// Drop it once the underlying entry would have expired anyway,
+			// so meta doesn't grow without bound for high key cardinality.
+			c.meta.Delete(params)
+		}
+	}
+
+	return c.getAndCache(ctx, params)
+}
+
+// getAndCache serves params from the cache layer, coalescing concurrent
+// misses through singleflight unless DisableCoalesce is set.
+func (c *FnCache[FnParams, FnReturns]) getAndCache(ctx context.Context, params FnParams) (FnReturns, error) {
 	result, err := c.cache.Get(ctx, params)
 	if err == nil {
 		return result, nil
 	}
 
-	result, err = c.getFn(ctx, params)
+	return c.coalescedFetch(ctx, params)
+}
 
-	if err == nil {
-		err = c.cache.Set(ctx, params, result)
+// forceRefetch discards whatever the CacheLayer currently holds for params
+// and performs a synchronous getFn call, the same as a genuine cache miss.
+// Used once an entry is past HardTTL, when the CacheLayer's own TTL isn't
+// guaranteed to have expired the stale value yet.
+func (c *FnCache[FnParams, FnReturns]) forceRefetch(ctx context.Context, params FnParams) (FnReturns, error) {
+	_ = c.cache.Delete(ctx, params)
+	return c.coalescedFetch(ctx, params)
+}
+
+// coalescedFetch calls fetchAndCache, coalescing concurrent callers for the
+// same params through singleflight unless DisableCoalesce is set.
+func (c *FnCache[FnParams, FnReturns]) coalescedFetch(ctx context.Context, params FnParams) (FnReturns, error) {
+	if c.DisableCoalesce {
+		return c.fetchAndCache(ctx, params)
 	}
 
-	return result, err
+	v, err, _ := c.group.Do(c.keyFunc(params), func() (any, error) {
+		return c.fetchAndCache(ctx, params)
+	})
+	if err != nil {
+		var zero FnReturns
+		return zero, err
+	}
+	return v.(FnReturns), nil
+}
+
+// refreshAsync triggers a background getFn call to refresh a stale entry,
+// deduplicating concurrent refresh requests for the same params.
+func (c *FnCache[FnParams, FnReturns]) refreshAsync(params FnParams) {
+	if _, loaded := c.refreshing.LoadOrStore(params, struct{}{}); loaded {
+		return
+	}
+
+	go func() {
+		defer c.refreshing.Delete(params)
+		_, _, _ = c.group.Do(c.keyFunc(params), func() (any, error) {
+			return c.fetchAndCache(context.Background(), params)
+		})
+	}()
+}
+
+// fetchAndCache calls getFn and, on success, populates the cache layer. A
+// getFn error classified as negative by IsNegative is recorded so that
+// subsequent Get calls short-circuit with ErrNegativeCached.
+func (c *FnCache[FnParams, FnReturns]) fetchAndCache(ctx context.Context, params FnParams) (FnReturns, error) {
+	result, err := c.getFn(ctx, params)
+	if err != nil {
+		if c.NegativeTTL > 0 && c.IsNegative != nil && c.IsNegative(err) {
+			c.markNegative(params)
+		}
+		return result, err
+	}
+
+	if err := c.cache.Set(ctx, params, result); err != nil {
+		return result, err
+	}
+	c.markFresh(params)
+	return result, nil
+}
+
+func (c *FnCache[FnParams, FnReturns]) loadMeta(params FnParams) (entryMeta, bool) {
+	v, ok := c.meta.Load(params)
+	if !ok {
+		return entryMeta{}, false
+	}
+	return v.(entryMeta), true
+}
+
+func (c *FnCache[FnParams, FnReturns]) markFresh(params FnParams) {
+	c.meta.Store(params, entryMeta{setAt: c.now()})
+}
+
+func (c *FnCache[FnParams, FnReturns]) markNegative(params FnParams) {
+	c.meta.Store(params, entryMeta{setAt: c.now(), negative: true})
+}
+
+// GetMulti resolves params in bulk, coalescing cache hits from a single
+// CacheLayer.GetMulti call with one getMultiFn call for whatever misses
+// remain. If getMultiFn is not set, getFn is called once per miss instead.
+func (c *FnCache[FnParams, FnReturns]) GetMulti(ctx context.Context, paramsList []FnParams) (map[FnParams]FnReturns, error) {
+	results := make(map[FnParams]FnReturns, len(paramsList))
+
+	hits, err := c.cache.GetMulti(ctx, paramsList)
+	if err != nil {
+		hits = nil
+	}
+
+	var misses []FnParams
+	for _, params := range paramsList {
+		if value, ok := hits[params]; ok {
+			results[params] = value
+		} else {
+			misses = append(misses, params)
+		}
+	}
+
+	if len(misses) == 0 {
+		return results, nil
+	}
+
+	fetched := make(map[FnParams]FnReturns, len(misses))
+	if c.getMultiFn != nil {
+		fetched, err = c.getMultiFn(ctx, misses)
+		if err != nil {
+			return results, err
+		}
+	} else {
+		for _, params := range misses {
+			value, err := c.getFn(ctx, params)
+			if err != nil {
+				// Flush whatever was already fetched successfully in this
+				// call before surfacing the error, rather than discarding
+				// it: misses are fetched in paramsList order, so a failure
+				// partway through would otherwise silently drop the results
+				// already fetched for earlier params.
+				for p, v := range fetched {
+					results[p] = v
+				}
+				if len(fetched) > 0 {
+					_ = c.cache.SetMulti(ctx, fetched)
+				}
+				return results, err
+			}
+			fetched[params] = value
+		}
+	}
+
+	for params, value := range fetched {
+		results[params] = value
+	}
+
+	if err := c.cache.SetMulti(ctx, fetched); err != nil {
+		return results, err
+	}
+
+	return results, nil
 }
 
 func (c *FnCache[FnParams, FnReturns]) Set(ctx context.Context, params FnParams, value FnReturns) error {
@@ -62,5 +340,9 @@ func (c *FnCache[FnParams, FnReturns]) Set(ctx context.Context, params FnParams,
 		return err
 	}
 
-	return c.cache.Set(ctx, params, value)
+	if err := c.cache.Set(ctx, params, value); err != nil {
+		return err
+	}
+	c.markFresh(params)
+	return nil
 }
@@ -3,6 +3,9 @@ package fncache_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -34,6 +37,36 @@ func (m *mockCacheLayer[K, V]) Set(ctx context.Context, key K, value V) error {
 	return nil
 }
 
+func (m *mockCacheLayer[K, V]) Delete(ctx context.Context, key K) error {
+	delete(m.store, key)
+	return nil
+}
+
+func (m *mockCacheLayer[K, V]) Exists(ctx context.Context, key K) (bool, error) {
+	_, ok := m.store[key]
+	return ok, nil
+}
+
+func (m *mockCacheLayer[K, V]) GetMulti(ctx context.Context, keys []K) (map[K]V, error) {
+	results := make(map[K]V)
+	for _, key := range keys {
+		if val, ok := m.store[key]; ok {
+			results[key] = val
+		}
+	}
+	return results, nil
+}
+
+func (m *mockCacheLayer[K, V]) SetMulti(ctx context.Context, values map[K]V) error {
+	if m.store == nil {
+		m.store = make(map[K]V)
+	}
+	for key, value := range values {
+		m.store[key] = value
+	}
+	return nil
+}
+
 func TestGetCacheMiss(t *testing.T) {
 	ctx := context.Background()
 	mockCache := &mockCacheLayer[int, string]{}
@@ -193,3 +226,481 @@ func TestContextCancellation(t *testing.T) {
 		t.Errorf("Expected context canceled error, got %v", err)
 	}
 }
+
+func TestGetMultiCoalescesHitsAndMisses(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	getCallCount := 0
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			getCallCount++
+			return "fetched", nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+		},
+	)
+
+	// Prime the cache for key 1 only.
+	if _, err := cache.Get(ctx, 1); err != nil {
+		t.Fatalf("Unexpected error priming cache: %v", err)
+	}
+	getCallCount = 0
+
+	results, err := cache.GetMulti(ctx, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("Expected 3 results, got %d", len(results))
+	}
+	if results[1] != "fetched" || results[2] != "fetched" || results[3] != "fetched" {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+	if getCallCount != 2 {
+		t.Errorf("Expected getFn to be called for 2 misses, got %d", getCallCount)
+	}
+}
+
+func TestGetMultiUsesGetMultiFn(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	bulkCallCount := 0
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			t.Fatal("getFn should not be called when getMultiFn is set")
+			return "", nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+		},
+		fncache.WithGetMultiFn(func(ctx context.Context, keys []int) (map[int]string, error) {
+			bulkCallCount++
+			results := make(map[int]string, len(keys))
+			for _, key := range keys {
+				results[key] = "bulk"
+			}
+			return results, nil
+		}),
+	)
+
+	results, err := cache.GetMulti(ctx, []int{1, 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bulkCallCount != 1 {
+		t.Errorf("Expected getMultiFn to be called once, got %d", bulkCallCount)
+	}
+	if results[1] != "bulk" || results[2] != "bulk" {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+}
+
+func TestGetMultiFlushesSuccessesBeforePartialFailure(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	errBoom := errors.New("boom")
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			if key == 2 {
+				return "", errBoom
+			}
+			return fmt.Sprintf("value-%d", key), nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+		},
+	)
+
+	results, err := cache.GetMulti(ctx, []int{1, 2, 3})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Expected errBoom, got %v", err)
+	}
+
+	// Key 1 is fetched before the key-2 failure (misses are walked in
+	// paramsList order when there are no cache hits), so it must still be
+	// returned and cached rather than discarded.
+	if results[1] != "value-1" {
+		t.Errorf("Expected value-1 to survive the partial failure, got %+v", results)
+	}
+	if _, ok := results[2]; ok {
+		t.Errorf("Expected no result for the failed key, got %+v", results)
+	}
+	if cached, err := mockCache.Get(ctx, 1); err != nil || cached != "value-1" {
+		t.Errorf("Expected value-1 to be cached despite the later failure, got %q (err: %v)", cached, err)
+	}
+}
+
+func TestGetCoalescesConcurrentMisses(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	var getCallCount int64
+	release := make(chan struct{})
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			atomic.AddInt64(&getCallCount, 1)
+			<-release
+			return "value", nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+		},
+	)
+
+	const concurrency = 100
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			val, err := cache.Get(ctx, 1)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if val != "value" {
+				t.Errorf("Expected 'value', got %q", val)
+			}
+		}()
+	}
+
+	// Give the goroutines a chance to pile up on the same in-flight call
+	// before letting getFn return.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if atomic.LoadInt64(&getCallCount) != 1 {
+		t.Errorf("Expected getFn to be called exactly once, got %d", getCallCount)
+	}
+}
+
+// sprintCollidingParams is a pair of distinct values whose fmt.Sprint
+// output is identical, demonstrating why the singleflight group key can't
+// be derived with fmt.Sprint.
+type sprintCollidingParams struct {
+	A, B string
+}
+
+func TestGetCoalesceDoesNotCollideAcrossDistinctParams(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[sprintCollidingParams, string]{}
+
+	cache := fncache.NewFnCache[sprintCollidingParams, string](
+		func(ctx context.Context, key sprintCollidingParams) (string, error) {
+			return fmt.Sprintf("%s|%s", key.A, key.B), nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+		},
+	)
+
+	first := sprintCollidingParams{A: "a b", B: "c"}
+	second := sprintCollidingParams{A: "a", B: "b c"}
+
+	// fmt.Sprint renders both identically ("{a b c}"), but they must still
+	// resolve to their own distinct getFn results.
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Fatalf("test assumption violated: %v and %v do not collide under fmt.Sprint", first, second)
+	}
+
+	gotFirst, err := cache.Get(ctx, first)
+	if err != nil {
+		t.Fatalf("Unexpected error for first: %v", err)
+	}
+	if gotFirst != "a b|c" {
+		t.Errorf("Expected %q for first, got %q", "a b|c", gotFirst)
+	}
+
+	gotSecond, err := cache.Get(ctx, second)
+	if err != nil {
+		t.Fatalf("Unexpected error for second: %v", err)
+	}
+	if gotSecond != "a|b c" {
+		t.Errorf("Expected %q for second, got %q", "a|b c", gotSecond)
+	}
+}
+
+func TestGetDisableCoalesceRunsGetFnPerMiss(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	var getCallCount int64
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			atomic.AddInt64(&getCallCount, 1)
+			return "value", nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration:   time.Minute,
+			DisableCoalesce: true,
+		},
+	)
+
+	const concurrency = 10
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Get(ctx, 2); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if atomic.LoadInt64(&getCallCount) == 0 {
+		t.Errorf("Expected getFn to be called at least once")
+	}
+}
+
+// fakeClock provides a mutable, concurrency-safe time source for tests that
+// need to cross SoftTTL/HardTTL/NegativeTTL boundaries deterministically.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+var errNotFound = errors.New("not found upstream")
+
+func TestGetNegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	clock := newFakeClock()
+	var getCallCount int64
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			atomic.AddInt64(&getCallCount, 1)
+			return "", errNotFound
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+			NegativeTTL:   time.Minute,
+			IsNegative: func(err error) bool {
+				return errors.Is(err, errNotFound)
+			},
+		},
+		fncache.WithClock[int, string](clock.Now),
+	)
+
+	if _, err := cache.Get(ctx, 1); !errors.Is(err, errNotFound) {
+		t.Fatalf("Expected errNotFound, got %v", err)
+	}
+	if atomic.LoadInt64(&getCallCount) != 1 {
+		t.Fatalf("Expected getFn to be called once, got %d", getCallCount)
+	}
+
+	// Within NegativeTTL, Get should short-circuit with ErrNegativeCached
+	// rather than calling getFn again.
+	if _, err := cache.Get(ctx, 1); !errors.Is(err, fncache.ErrNegativeCached) {
+		t.Errorf("Expected ErrNegativeCached, got %v", err)
+	}
+	if atomic.LoadInt64(&getCallCount) != 1 {
+		t.Errorf("Expected getFn not to be called again, got %d calls", getCallCount)
+	}
+
+	// Once NegativeTTL elapses, Get should call getFn again.
+	clock.Advance(2 * time.Minute)
+	if _, err := cache.Get(ctx, 1); !errors.Is(err, errNotFound) {
+		t.Errorf("Expected errNotFound after NegativeTTL elapsed, got %v", err)
+	}
+	if atomic.LoadInt64(&getCallCount) != 2 {
+		t.Errorf("Expected getFn to be called again after NegativeTTL elapsed, got %d", getCallCount)
+	}
+}
+
+func TestGetNegativeCachingDisabledWithZeroTTL(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	clock := newFakeClock()
+	var getCallCount int64
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			atomic.AddInt64(&getCallCount, 1)
+			return "", errNotFound
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+			// NegativeTTL left unset: IsNegative alone must not enable
+			// negative caching, or a classified error would stick forever.
+			IsNegative: func(err error) bool {
+				return errors.Is(err, errNotFound)
+			},
+		},
+		fncache.WithClock[int, string](clock.Now),
+	)
+
+	if _, err := cache.Get(ctx, 1); !errors.Is(err, errNotFound) {
+		t.Fatalf("Expected errNotFound, got %v", err)
+	}
+	if _, err := cache.Get(ctx, 1); !errors.Is(err, errNotFound) {
+		t.Errorf("Expected errNotFound again with NegativeTTL unset, got %v", err)
+	}
+	if atomic.LoadInt64(&getCallCount) != 2 {
+		t.Errorf("Expected getFn to be called on every miss with NegativeTTL unset, got %d", getCallCount)
+	}
+}
+
+func TestGetStaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+	mockCache := &mockCacheLayer[int, string]{}
+	clock := newFakeClock()
+	var getCallCount int64
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			n := atomic.AddInt64(&getCallCount, 1)
+			return fmt.Sprintf("value-%d", n), nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+			SoftTTL:       time.Second,
+			HardTTL:       time.Hour,
+		},
+		fncache.WithClock[int, string](clock.Now),
+	)
+
+	val, err := cache.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val != "value-1" {
+		t.Fatalf("Expected value-1, got %q", val)
+	}
+
+	// Still within SoftTTL: serve the fresh value without refreshing.
+	val, err = cache.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val != "value-1" {
+		t.Errorf("Expected value-1, got %q", val)
+	}
+	if atomic.LoadInt64(&getCallCount) != 1 {
+		t.Errorf("Expected getFn to be called once, got %d", getCallCount)
+	}
+
+	// Past SoftTTL but within HardTTL: serve the stale value immediately,
+	// and asynchronously trigger a refresh.
+	clock.Advance(2 * time.Second)
+	val, err = cache.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val != "value-1" {
+		t.Errorf("Expected stale value-1 to be served immediately, got %q", val)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt64(&getCallCount) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if atomic.LoadInt64(&getCallCount) != 2 {
+		t.Fatalf("Expected async refresh to call getFn a second time, got %d", getCallCount)
+	}
+
+	deadline = time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if v, err := mockCache.Get(ctx, 1); err == nil && v == "value-2" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	val, err = mockCache.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val != "value-2" {
+		t.Errorf("Expected cache to be refreshed to value-2, got %q", val)
+	}
+}
+
+func TestGetForcesSynchronousRefetchPastHardTTL(t *testing.T) {
+	ctx := context.Background()
+	// mockCacheLayer never expires entries on its own, standing in for a
+	// CacheLayer whose independently configured TTL outlives HardTTL.
+	mockCache := &mockCacheLayer[int, string]{}
+	clock := newFakeClock()
+	var getCallCount int64
+
+	cache := fncache.NewFnCache[int, string](
+		func(ctx context.Context, key int) (string, error) {
+			n := atomic.AddInt64(&getCallCount, 1)
+			return fmt.Sprintf("value-%d", n), nil
+		},
+		nil,
+		mockCache,
+		fncache.CacheConfig{
+			CacheDuration: time.Minute,
+			HardTTL:       time.Second,
+		},
+		fncache.WithClock[int, string](clock.Now),
+	)
+
+	if val, err := cache.Get(ctx, 1); err != nil || val != "value-1" {
+		t.Fatalf("Expected value-1, got %q (err: %v)", val, err)
+	}
+
+	// Still within HardTTL: the mock cache hit is served as-is.
+	if val, err := cache.Get(ctx, 1); err != nil || val != "value-1" {
+		t.Fatalf("Expected value-1, got %q (err: %v)", val, err)
+	}
+	if atomic.LoadInt64(&getCallCount) != 1 {
+		t.Fatalf("Expected getFn to be called once, got %d", getCallCount)
+	}
+
+	// Past HardTTL: even though mockCacheLayer's own entry is still live
+	// (it has no TTL of its own), Get must not keep serving it forever -
+	// it should force a synchronous refetch, the same as a cache miss.
+	clock.Advance(2 * time.Second)
+	val, err := cache.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val != "value-2" {
+		t.Errorf("Expected forced refetch to return value-2, got %q", val)
+	}
+	if atomic.LoadInt64(&getCallCount) != 2 {
+		t.Fatalf("Expected getFn to be called synchronously on the same Get, got %d calls", getCallCount)
+	}
+}
@@ -0,0 +1,53 @@
+package fncache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+)
+
+// KeyFunc derives an opaque string key from FnParams. CacheLayer
+// implementations that need a string representation of FnParams (e.g. for
+// a Redis key or an event bus topic) accept a KeyFunc so callers can pick
+// one that suits their domain, or supply their own (e.g. a canonical URL).
+type KeyFunc[FnParams any] func(FnParams) string
+
+// KeyFuncFNVSprint derives a key by hashing fmt.Sprint(params) with
+// FNV-64a. It is fast, but can silently collide for values whose Sprint
+// output is ambiguous (e.g. struct{A, B string}{"a b", "c"} vs
+// {"a", "b c"}), and truncates to 64 bits. This is the historical
+// RedisCache key derivation, kept as the default for backward
+// compatibility.
+func KeyFuncFNVSprint[FnParams any](params FnParams) string {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(fmt.Sprint(params)))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// KeyFuncGobSHA256 derives a collision-resistant key by gob-encoding
+// params and hashing the result with SHA-256. FnParams must be
+// gob-encodable.
+func KeyFuncGobSHA256[FnParams any](params FnParams) string {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(params); err != nil {
+		return "gob-error:" + fmt.Sprint(params)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
+
+// KeyFuncJSONSHA256 derives a collision-resistant key by JSON-encoding
+// params and hashing the result with SHA-256. Unlike KeyFuncGobSHA256,
+// FnParams does not need to be gob-encodable.
+func KeyFuncJSONSHA256[FnParams any](params FnParams) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "json-error:" + fmt.Sprint(params)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,43 @@
+package fncache_test
+
+import (
+	"testing"
+
+	"github.com/NodePath81/fncache"
+)
+
+type keyFuncTestParams struct {
+	A string
+	B string
+}
+
+func TestKeyFuncFNVSprintIsDeterministic(t *testing.T) {
+	p := keyFuncTestParams{A: "a", B: "b"}
+	if fncache.KeyFuncFNVSprint(p) != fncache.KeyFuncFNVSprint(p) {
+		t.Error("Expected KeyFuncFNVSprint to be deterministic for the same value")
+	}
+}
+
+func TestKeyFuncGobSHA256DistinguishesAmbiguousSprint(t *testing.T) {
+	a := keyFuncTestParams{A: "a b", B: "c"}
+	b := keyFuncTestParams{A: "a", B: "b c"}
+
+	// fmt.Sprint renders both as "{a b c}", so the FNV-based key collides...
+	if fncache.KeyFuncFNVSprint(a) != fncache.KeyFuncFNVSprint(b) {
+		t.Fatal("Expected KeyFuncFNVSprint to collide on this ambiguous pair (sanity check)")
+	}
+
+	// ...but the gob-based key must not.
+	if fncache.KeyFuncGobSHA256(a) == fncache.KeyFuncGobSHA256(b) {
+		t.Error("Expected KeyFuncGobSHA256 to distinguish ambiguous Sprint output")
+	}
+}
+
+func TestKeyFuncJSONSHA256DistinguishesAmbiguousSprint(t *testing.T) {
+	a := keyFuncTestParams{A: "a b", B: "c"}
+	b := keyFuncTestParams{A: "a", B: "b c"}
+
+	if fncache.KeyFuncJSONSHA256(a) == fncache.KeyFuncJSONSHA256(b) {
+		t.Error("Expected KeyFuncJSONSHA256 to distinguish ambiguous Sprint output")
+	}
+}
@@ -0,0 +1,197 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type lfuEntry[FnReturns any] struct {
+	value     FnReturns
+	size      int64
+	freq      int64
+	seq       int64 // insertion order, used to break freq ties in favor of evicting the older entry
+	expiresAt time.Time
+}
+
+// LFUCache is a bounded, least-frequently-used CacheLayer, offered as an
+// alternative eviction policy to Cache's LRU. Like Cache, entries also
+// expire after ttl and usage is reported through Stats.
+type LFUCache[FnParams comparable, FnReturns any] struct {
+	ttl      time.Duration
+	maxKeys  int
+	maxBytes int64
+	sizer    Sizer[FnReturns]
+
+	mu      sync.Mutex
+	items   map[FnParams]*lfuEntry[FnReturns]
+	nextSeq int64
+
+	bytes     int64
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// LFUOption configures an LFUCache constructed via NewLFUCache.
+type LFUOption[FnParams comparable, FnReturns any] func(*LFUCache[FnParams, FnReturns])
+
+// WithLFUMaxBytes bounds total estimated size, evicting the
+// least-frequently-used entries first once the bound is exceeded.
+func WithLFUMaxBytes[FnParams comparable, FnReturns any](maxBytes int64, sizer Sizer[FnReturns]) LFUOption[FnParams, FnReturns] {
+	return func(c *LFUCache[FnParams, FnReturns]) {
+		c.maxBytes = maxBytes
+		c.sizer = sizer
+	}
+}
+
+// NewLFUCache creates an LFUCache holding at most maxKeys entries, each
+// living for ttl. Use WithLFUMaxBytes to additionally bound total
+// estimated size.
+func NewLFUCache[FnParams comparable, FnReturns any](ttl time.Duration, maxKeys int, options ...LFUOption[FnParams, FnReturns]) *LFUCache[FnParams, FnReturns] {
+	c := &LFUCache[FnParams, FnReturns]{
+		ttl:     ttl,
+		maxKeys: maxKeys,
+		items:   make(map[FnParams]*lfuEntry[FnReturns]),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Get retrieves the cached value for params.
+func (c *LFUCache[FnParams, FnReturns]) Get(ctx context.Context, params FnParams) (FnReturns, error) {
+	var zero FnReturns
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[params]
+	if !ok {
+		c.misses++
+		return zero, errors.New("cache miss")
+	}
+	if time.Now().After(e.expiresAt) {
+		c.removeLocked(params, e)
+		c.misses++
+		return zero, errors.New("cache expired")
+	}
+	e.freq++
+	c.hits++
+	return e.value, nil
+}
+
+// Set stores value for params, evicting least-frequently-used entries if
+// doing so exceeds MaxKeys or MaxBytes.
+func (c *LFUCache[FnParams, FnReturns]) Set(ctx context.Context, params FnParams, value FnReturns) error {
+	var size int64
+	if c.sizer != nil {
+		size = c.sizer(value)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.items[params]; ok {
+		c.bytes -= old.size
+	}
+	c.items[params] = &lfuEntry[FnReturns]{
+		value:     value,
+		size:      size,
+		seq:       c.nextSeq,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.nextSeq++
+	c.bytes += size
+
+	c.evictLocked()
+	return nil
+}
+
+// Delete removes the cached value for params.
+func (c *LFUCache[FnParams, FnReturns]) Delete(ctx context.Context, params FnParams) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[params]; ok {
+		c.removeLocked(params, e)
+	}
+	return nil
+}
+
+// Exists reports whether params has a live, unexpired entry in the cache.
+func (c *LFUCache[FnParams, FnReturns]) Exists(ctx context.Context, params FnParams) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[params]
+	if !ok || time.Now().After(e.expiresAt) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// GetMulti returns whichever of params are present and unexpired, omitting
+// the rest rather than erroring.
+func (c *LFUCache[FnParams, FnReturns]) GetMulti(ctx context.Context, paramsList []FnParams) (map[FnParams]FnReturns, error) {
+	results := make(map[FnParams]FnReturns, len(paramsList))
+	for _, p := range paramsList {
+		if value, err := c.Get(ctx, p); err == nil {
+			results[p] = value
+		}
+	}
+	return results, nil
+}
+
+// SetMulti stores every key/value pair in values.
+func (c *LFUCache[FnParams, FnReturns]) SetMulti(ctx context.Context, values map[FnParams]FnReturns) error {
+	for p, value := range values {
+		if err := c.Set(ctx, p, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the cache's usage counters.
+func (c *LFUCache[FnParams, FnReturns]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Bytes: c.bytes}
+}
+
+// removeLocked deletes params from items and updates bytes/evictions. c.mu
+// must be held.
+func (c *LFUCache[FnParams, FnReturns]) removeLocked(params FnParams, e *lfuEntry[FnReturns]) {
+	delete(c.items, params)
+	c.bytes -= e.size
+	c.evictions++
+}
+
+// evictLocked removes least-frequently-used entries until both MaxKeys and
+// MaxBytes are satisfied. c.mu must be held.
+func (c *LFUCache[FnParams, FnReturns]) evictLocked() {
+	for c.overCapacityLocked() {
+		var victim FnParams
+		var victimEntry *lfuEntry[FnReturns]
+		found := false
+		for k, e := range c.items {
+			if !found || e.freq < victimEntry.freq || (e.freq == victimEntry.freq && e.seq < victimEntry.seq) {
+				victim, victimEntry, found = k, e, true
+			}
+		}
+		if !found {
+			return
+		}
+		c.removeLocked(victim, victimEntry)
+	}
+}
+
+func (c *LFUCache[FnParams, FnReturns]) overCapacityLocked() bool {
+	if c.maxKeys > 0 && len(c.items) > c.maxKeys {
+		return true
+	}
+	if c.maxBytes > 0 && c.bytes > c.maxBytes {
+		return true
+	}
+	return false
+}
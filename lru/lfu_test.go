@@ -0,0 +1,90 @@
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLFUCache_SetGet(t *testing.T) {
+	cache := NewLFUCache[string, int](time.Minute, 10)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "foo", 42); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	got, err := cache.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != 42 {
+		t.Errorf("Expected 42, got %d", got)
+	}
+}
+
+func TestLFUCache_Expiration(t *testing.T) {
+	cache := NewLFUCache[string, int](100*time.Millisecond, 10)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "bar", 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	if _, err := cache.Get(ctx, "bar"); err == nil {
+		t.Error("Expected error after expiration")
+	}
+}
+
+func TestLFUCache_EvictsLeastFrequentlyUsed(t *testing.T) {
+	cache := NewLFUCache[int, int](time.Minute, 2)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, 1, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Set(ctx, 2, 2); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// Access key 1 repeatedly so it is more frequently used than key 2.
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Get(ctx, 1); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+	}
+
+	if err := cache.Set(ctx, 3, 3); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if _, err := cache.Get(ctx, 1); err != nil {
+		t.Error("Expected frequently used key 1 to survive eviction")
+	}
+	if _, err := cache.Get(ctx, 2); err == nil {
+		t.Error("Expected least-frequently-used key 2 to have been evicted")
+	}
+
+	if stats := cache.Stats(); stats.Evictions != 1 {
+		t.Errorf("Expected 1 eviction, got %+v", stats)
+	}
+}
+
+func TestLFUCache_DeleteAndExists(t *testing.T) {
+	cache := NewLFUCache[string, int](time.Minute, 10)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "baz", 7); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ok, err := cache.Exists(ctx, "baz"); err != nil || !ok {
+		t.Fatalf("Expected Exists to be true, got %v (err: %v)", ok, err)
+	}
+
+	if err := cache.Delete(ctx, "baz"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if ok, err := cache.Exists(ctx, "baz"); err != nil || ok {
+		t.Fatalf("Expected Exists to be false after delete, got %v (err: %v)", ok, err)
+	}
+}
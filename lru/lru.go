@@ -0,0 +1,185 @@
+// Package lru provides bounded, in-memory CacheLayer implementations that
+// evict entries once a key-count or byte-size budget is exceeded, unlike
+// memory.InMemoryCache which relies solely on TTL and weak pointers.
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	hashicorplru "github.com/hashicorp/golang-lru/v2"
+)
+
+// Sizer estimates the size in bytes of a cached value, used to enforce a
+// MaxBytes budget.
+type Sizer[FnReturns any] func(FnReturns) int64
+
+// Stats reports cache usage counters.
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Bytes     int64
+}
+
+type entry[FnReturns any] struct {
+	value     FnReturns
+	size      int64
+	expiresAt time.Time
+}
+
+// Cache is a bounded, least-recently-used CacheLayer. Entries also expire
+// after ttl, matching memory.InMemoryCache's TTL semantics.
+type Cache[FnParams comparable, FnReturns any] struct {
+	ttl      time.Duration
+	maxBytes int64
+	sizer    Sizer[FnReturns]
+	cache    *hashicorplru.Cache[FnParams, *entry[FnReturns]]
+
+	bytes     int64
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// Option configures a Cache constructed via NewCache.
+type Option[FnParams comparable, FnReturns any] func(*Cache[FnParams, FnReturns])
+
+// WithMaxBytes bounds the total estimated size of cached values, evicting
+// the least-recently-used entries first once the bound is exceeded. sizer
+// estimates the size of each value; without WithMaxBytes, only MaxKeys
+// (via NewCache) bounds the cache.
+func WithMaxBytes[FnParams comparable, FnReturns any](maxBytes int64, sizer Sizer[FnReturns]) Option[FnParams, FnReturns] {
+	return func(c *Cache[FnParams, FnReturns]) {
+		c.maxBytes = maxBytes
+		c.sizer = sizer
+	}
+}
+
+// NewCache creates a Cache holding at most maxKeys entries, each living for
+// ttl. Use WithMaxBytes to additionally bound total estimated size.
+func NewCache[FnParams comparable, FnReturns any](ttl time.Duration, maxKeys int, options ...Option[FnParams, FnReturns]) (*Cache[FnParams, FnReturns], error) {
+	c := &Cache[FnParams, FnReturns]{
+		ttl: ttl,
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	inner, err := hashicorplru.NewWithEvict[FnParams, *entry[FnReturns]](maxKeys, c.onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.cache = inner
+	return c, nil
+}
+
+// onEvict is invoked by the underlying LRU for every key it removes,
+// whether due to capacity, an explicit Remove, or RemoveOldest called from
+// enforceMaxBytes.
+func (c *Cache[FnParams, FnReturns]) onEvict(_ FnParams, e *entry[FnReturns]) {
+	atomic.AddInt64(&c.bytes, -e.size)
+	atomic.AddInt64(&c.evictions, 1)
+}
+
+// Get retrieves the cached value for params.
+func (c *Cache[FnParams, FnReturns]) Get(ctx context.Context, params FnParams) (FnReturns, error) {
+	var zero FnReturns
+	e, ok := c.cache.Get(params)
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return zero, errors.New("cache miss")
+	}
+	if time.Now().After(e.expiresAt) {
+		c.cache.Remove(params)
+		atomic.AddInt64(&c.misses, 1)
+		return zero, errors.New("cache expired")
+	}
+	atomic.AddInt64(&c.hits, 1)
+	return e.value, nil
+}
+
+// Set stores value for params, evicting least-recently-used entries if
+// doing so exceeds MaxKeys or MaxBytes.
+func (c *Cache[FnParams, FnReturns]) Set(ctx context.Context, params FnParams, value FnReturns) error {
+	var size int64
+	if c.sizer != nil {
+		size = c.sizer(value)
+	}
+
+	if old, ok := c.cache.Peek(params); ok {
+		atomic.AddInt64(&c.bytes, -old.size)
+	}
+
+	c.cache.Add(params, &entry[FnReturns]{
+		value:     value,
+		size:      size,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+	atomic.AddInt64(&c.bytes, size)
+
+	c.enforceMaxBytes()
+	return nil
+}
+
+// enforceMaxBytes evicts the least-recently-used entry, repeatedly, until
+// total estimated size is back under MaxBytes.
+func (c *Cache[FnParams, FnReturns]) enforceMaxBytes() {
+	if c.maxBytes <= 0 {
+		return
+	}
+	for atomic.LoadInt64(&c.bytes) > c.maxBytes {
+		if _, _, ok := c.cache.RemoveOldest(); !ok {
+			return
+		}
+	}
+}
+
+// Delete removes the cached value for params.
+func (c *Cache[FnParams, FnReturns]) Delete(ctx context.Context, params FnParams) error {
+	c.cache.Remove(params)
+	return nil
+}
+
+// Exists reports whether params has a live, unexpired entry in the cache.
+func (c *Cache[FnParams, FnReturns]) Exists(ctx context.Context, params FnParams) (bool, error) {
+	e, ok := c.cache.Peek(params)
+	if !ok {
+		return false, nil
+	}
+	return !time.Now().After(e.expiresAt), nil
+}
+
+// GetMulti returns whichever of params are present and unexpired, omitting
+// the rest rather than erroring.
+func (c *Cache[FnParams, FnReturns]) GetMulti(ctx context.Context, params []FnParams) (map[FnParams]FnReturns, error) {
+	results := make(map[FnParams]FnReturns, len(params))
+	for _, p := range params {
+		if value, err := c.Get(ctx, p); err == nil {
+			results[p] = value
+		}
+	}
+	return results, nil
+}
+
+// SetMulti stores every key/value pair in values.
+func (c *Cache[FnParams, FnReturns]) SetMulti(ctx context.Context, values map[FnParams]FnReturns) error {
+	for p, value := range values {
+		if err := c.Set(ctx, p, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the cache's usage counters.
+func (c *Cache[FnParams, FnReturns]) Stats() Stats {
+	return Stats{
+		Hits:      atomic.LoadInt64(&c.hits),
+		Misses:    atomic.LoadInt64(&c.misses),
+		Evictions: atomic.LoadInt64(&c.evictions),
+		Bytes:     atomic.LoadInt64(&c.bytes),
+	}
+}
@@ -0,0 +1,139 @@
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCache_SetGet(t *testing.T) {
+	cache, err := NewCache[string, int](time.Minute, 10)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "foo", 42); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	got, err := cache.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != 42 {
+		t.Errorf("Expected 42, got %d", got)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 0 {
+		t.Errorf("Unexpected stats after hit: %+v", stats)
+	}
+}
+
+func TestCache_Expiration(t *testing.T) {
+	cache, err := NewCache[string, int](100*time.Millisecond, 10)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "bar", 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	if _, err := cache.Get(ctx, "bar"); err == nil {
+		t.Error("Expected error after expiration")
+	}
+	if stats := cache.Stats(); stats.Misses != 1 {
+		t.Errorf("Expected 1 miss, got %+v", stats)
+	}
+}
+
+func TestCache_EvictsOverMaxKeys(t *testing.T) {
+	cache, err := NewCache[int, int](time.Minute, 2)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, 1, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Set(ctx, 2, 2); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Set(ctx, 3, 3); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if _, err := cache.Get(ctx, 1); err == nil {
+		t.Error("Expected key 1 to have been evicted")
+	}
+	if stats := cache.Stats(); stats.Evictions != 1 {
+		t.Errorf("Expected 1 eviction, got %+v", stats)
+	}
+}
+
+func TestCache_EvictsOverMaxBytes(t *testing.T) {
+	sizer := func(v string) int64 { return int64(len(v)) }
+	cache, err := NewCache[string, string](time.Minute, 10, WithMaxBytes[string, string](5, sizer))
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "a", "abc"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Set(ctx, "b", "abc"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if _, err := cache.Get(ctx, "a"); err == nil {
+		t.Error("Expected key a to have been evicted to respect MaxBytes")
+	}
+	if stats := cache.Stats(); stats.Bytes > 5 {
+		t.Errorf("Expected total bytes to stay within budget, got %+v", stats)
+	}
+}
+
+func TestCache_DeleteAndExists(t *testing.T) {
+	cache, err := NewCache[string, int](time.Minute, 10)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "baz", 7); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ok, err := cache.Exists(ctx, "baz"); err != nil || !ok {
+		t.Fatalf("Expected Exists to be true, got %v (err: %v)", ok, err)
+	}
+
+	if err := cache.Delete(ctx, "baz"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if ok, err := cache.Exists(ctx, "baz"); err != nil || ok {
+		t.Fatalf("Expected Exists to be false after delete, got %v (err: %v)", ok, err)
+	}
+}
+
+func TestCache_GetMultiSetMulti(t *testing.T) {
+	cache, err := NewCache[string, int](time.Minute, 10)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cache.SetMulti(ctx, map[string]int{"a": 1, "b": 2}); err != nil {
+		t.Fatalf("SetMulti failed: %v", err)
+	}
+
+	got, err := cache.GetMulti(ctx, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("GetMulti failed: %v", err)
+	}
+	if len(got) != 2 || got["a"] != 1 || got["b"] != 2 {
+		t.Errorf("Unexpected results: %+v", got)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"sync"
 	"time"
 	"weak"
+
+	"github.com/NodePath81/fncache"
+	"github.com/NodePath81/fncache/eventbus"
 )
 
 type cacheEntry[FnReturns any] struct {
@@ -16,19 +19,68 @@ type cacheEntry[FnReturns any] struct {
 }
 
 type InMemoryCache[FnParams comparable, FnReturns any] struct {
-	ttl   time.Duration
-	cache sync.Map // maps FnParams to *cacheEntry[FnReturns]
-	done  chan struct{}
+	ttl     time.Duration
+	cache   sync.Map // maps FnParams to *cacheEntry[FnReturns]
+	keys    sync.Map // maps keyFunc(FnParams) to FnParams, used for event-bus invalidation
+	done    chan struct{}
+	bus     eventbus.EventBus
+	keyFunc fncache.KeyFunc[FnParams]
+}
+
+// Option configures an InMemoryCache constructed via NewInMemoryCache.
+type Option[FnParams comparable, FnReturns any] func(*InMemoryCache[FnParams, FnReturns])
+
+// WithEventBus subscribes the cache to bus, so that an invalidation event
+// published by another node (or another cache tier on this node) evicts
+// the matching local entry.
+func WithEventBus[FnParams comparable, FnReturns any](bus eventbus.EventBus) Option[FnParams, FnReturns] {
+	return func(c *InMemoryCache[FnParams, FnReturns]) {
+		c.bus = bus
+	}
 }
 
-func NewInMemoryCache[FnParams comparable, FnReturns any](ttl time.Duration) *InMemoryCache[FnParams, FnReturns] {
+// WithKeyFunc overrides how FnParams is turned into the event-bus topic
+// used for invalidation (see WithEventBus). It has no effect on how
+// entries are stored internally, since FnParams is already used directly
+// as the cache's own map key. The default is fncache.KeyFuncFNVSprint.
+func WithKeyFunc[FnParams comparable, FnReturns any](fn fncache.KeyFunc[FnParams]) Option[FnParams, FnReturns] {
+	return func(c *InMemoryCache[FnParams, FnReturns]) {
+		c.keyFunc = fn
+	}
+}
+
+func NewInMemoryCache[FnParams comparable, FnReturns any](ttl time.Duration, options ...Option[FnParams, FnReturns]) *InMemoryCache[FnParams, FnReturns] {
 	c := &InMemoryCache[FnParams, FnReturns]{
-		ttl:  ttl,
-		done: make(chan struct{}),
+		ttl:     ttl,
+		done:    make(chan struct{}),
+		keyFunc: fncache.KeyFuncFNVSprint[FnParams],
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if c.bus != nil {
+		go c.subscribe()
 	}
 	return c
 }
 
+// subscribe evicts local entries as invalidation events arrive on c.bus,
+// until c.done is closed.
+func (c *InMemoryCache[FnParams, FnReturns]) subscribe() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-c.done
+		cancel()
+	}()
+
+	_ = c.bus.Subscribe(ctx, func(key string) {
+		if params, ok := c.keys.Load(key); ok {
+			c.cache.Delete(params)
+			c.keys.Delete(key)
+		}
+	})
+}
+
 func (c *InMemoryCache[FnParams, FnReturns]) Get(ctx context.Context, params FnParams) (FnReturns, error) {
 	var zero FnReturns
 	val, ok := c.cache.Load(params)
@@ -61,11 +113,17 @@ func (c *InMemoryCache[FnParams, FnReturns]) Set(ctx context.Context, params FnP
 	}
 
 	c.cache.Store(params, entry)
+	if c.bus != nil {
+		c.keys.Store(c.keyFunc(params), params)
+	}
 
 	cancelCh := make(chan struct{})
 
 	runtime.AddCleanup[FnReturns, FnParams](ptr, func(fp FnParams) {
 		c.cache.Delete(fp)
+		if c.bus != nil {
+			c.keys.Delete(c.keyFunc(fp))
+		}
 		close(cancelCh)
 	}, params)
 
@@ -82,6 +140,9 @@ func (c *InMemoryCache[FnParams, FnReturns]) Set(ctx context.Context, params FnP
 			if _, ok := c.cache.Load(params); ok {
 				c.cache.Delete(params)
 			}
+			if c.bus != nil {
+				c.keys.Delete(c.keyFunc(params))
+			}
 		}
 	}()
 
@@ -89,8 +150,43 @@ func (c *InMemoryCache[FnParams, FnReturns]) Set(ctx context.Context, params FnP
 	return nil
 }
 
-func (c *InMemoryCache[FnParams, FnReturns]) Delete(ctx context.Context, params FnParams) {
+func (c *InMemoryCache[FnParams, FnReturns]) Delete(ctx context.Context, params FnParams) error {
 	c.cache.Delete(params)
+	if c.bus != nil {
+		c.keys.Delete(c.keyFunc(params))
+	}
+	return nil
+}
+
+// Exists reports whether params has a live, unexpired entry in the cache.
+func (c *InMemoryCache[FnParams, FnReturns]) Exists(ctx context.Context, params FnParams) (bool, error) {
+	_, err := c.Get(ctx, params)
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// GetMulti returns whichever of params are present and unexpired, omitting
+// the rest rather than erroring.
+func (c *InMemoryCache[FnParams, FnReturns]) GetMulti(ctx context.Context, params []FnParams) (map[FnParams]FnReturns, error) {
+	results := make(map[FnParams]FnReturns, len(params))
+	for _, p := range params {
+		if value, err := c.Get(ctx, p); err == nil {
+			results[p] = value
+		}
+	}
+	return results, nil
+}
+
+// SetMulti stores every key/value pair in values.
+func (c *InMemoryCache[FnParams, FnReturns]) SetMulti(ctx context.Context, values map[FnParams]FnReturns) error {
+	for p, value := range values {
+		if err := c.Set(ctx, p, value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *InMemoryCache[FnParams, FnReturns]) Stop() {
@@ -2,10 +2,38 @@ package memory
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/NodePath81/fncache"
 )
 
+// fakeEventBus is an in-process EventBus used to test WithEventBus without
+// a real Redis server.
+type fakeEventBus struct {
+	mu       sync.Mutex
+	handlers []func(key string)
+}
+
+func (b *fakeEventBus) Publish(ctx context.Context, key string) error {
+	b.mu.Lock()
+	handlers := append([]func(key string){}, b.handlers...)
+	b.mu.Unlock()
+	for _, h := range handlers {
+		h(key)
+	}
+	return nil
+}
+
+func (b *fakeEventBus) Subscribe(ctx context.Context, handler func(key string)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mu.Unlock()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
 func TestInMemoryCache_SetGet(t *testing.T) {
 	cache := NewInMemoryCache[string, int](500 * time.Millisecond)
 	defer cache.Stop()
@@ -67,6 +95,126 @@ func TestInMemoryCache_Delete(t *testing.T) {
 	}
 }
 
+func TestInMemoryCache_Exists(t *testing.T) {
+	cache := NewInMemoryCache[string, int](500 * time.Millisecond)
+	defer cache.Stop()
+	ctx := context.Background()
+
+	if ok, err := cache.Exists(ctx, "missing"); err != nil || ok {
+		t.Fatalf("Expected Exists to be false for missing key, got %v (err: %v)", ok, err)
+	}
+
+	if err := cache.Set(ctx, "present", 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ok, err := cache.Exists(ctx, "present"); err != nil || !ok {
+		t.Fatalf("Expected Exists to be true for present key, got %v (err: %v)", ok, err)
+	}
+}
+
+func TestInMemoryCache_GetMultiSetMulti(t *testing.T) {
+	cache := NewInMemoryCache[string, int](500 * time.Millisecond)
+	defer cache.Stop()
+	ctx := context.Background()
+
+	if err := cache.SetMulti(ctx, map[string]int{"a": 1, "b": 2}); err != nil {
+		t.Fatalf("SetMulti failed: %v", err)
+	}
+
+	got, err := cache.GetMulti(ctx, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("GetMulti failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(got))
+	}
+	if got["a"] != 1 || got["b"] != 2 {
+		t.Errorf("Unexpected results: %+v", got)
+	}
+	if _, ok := got["c"]; ok {
+		t.Errorf("Expected missing key c to be omitted, got %v", got["c"])
+	}
+}
+
+func TestInMemoryCache_EventBusEvictsOnRemoteEvent(t *testing.T) {
+	bus := &fakeEventBus{}
+	cache := NewInMemoryCache[string, int](time.Minute, WithEventBus[string, int](bus))
+	defer cache.Stop()
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "shared", 42); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// Give the subscribe goroutine time to register its handler.
+	time.Sleep(10 * time.Millisecond)
+
+	if err := bus.Publish(ctx, fncache.KeyFuncFNVSprint("shared")); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if got, err := cache.Get(ctx, "shared"); err == nil {
+		t.Errorf("Expected entry to be evicted after remote event, got %d", got)
+	}
+}
+
+func TestInMemoryCache_EventBusKeysPrunedOnPassiveExpiry(t *testing.T) {
+	bus := &fakeEventBus{}
+	cache := NewInMemoryCache[string, int](20*time.Millisecond, WithEventBus[string, int](bus))
+	defer cache.Stop()
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "shared", 42); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// Let the entry expire passively via its TTL ticker, not via Delete.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := cache.cache.Load("shared"); !ok {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, ok := cache.cache.Load("shared"); ok {
+		t.Fatalf("Expected entry to expire via TTL before the test deadline")
+	}
+
+	// Give the ticker goroutine's c.keys.Delete a moment to run.
+	deadline = time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := cache.keys.Load(fncache.KeyFuncFNVSprint("shared")); !ok {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("Expected keys bookkeeping to be pruned once the entry passively expired, but it still holds the key")
+}
+
+func TestInMemoryCache_EventBusEvictionUsesCustomKeyFunc(t *testing.T) {
+	bus := &fakeEventBus{}
+	cache := NewInMemoryCache[string, int](time.Minute,
+		WithEventBus[string, int](bus),
+		WithKeyFunc[string, int](fncache.KeyFuncJSONSHA256[string]))
+	defer cache.Stop()
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "shared", 7); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// Publish using the same custom KeyFunc a peer using this cache's
+	// convention would use.
+	if err := bus.Publish(ctx, fncache.KeyFuncJSONSHA256("shared")); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if got, err := cache.Get(ctx, "shared"); err == nil {
+		t.Errorf("Expected entry to be evicted after remote event, got %d", got)
+	}
+}
+
 func TestInMemoryCache_Stop(t *testing.T) {
 	cache := NewInMemoryCache[string, int](500 * time.Millisecond)
 	// Ensure Stop does not panic.
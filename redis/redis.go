@@ -1,67 +1,90 @@
 package redis
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
-	"encoding/hex"
 	"fmt"
-	"hash/fnv"
 	"time"
 
+	"github.com/NodePath81/fncache"
+	"github.com/NodePath81/fncache/eventbus"
 	"github.com/redis/go-redis/v9"
 )
 
-// RedisCache implements CacheLayer using Redis with gob serialization.
-// FnReturns must be gob-encodable.
+// RedisCache implements CacheLayer using Redis. Values are serialized with
+// the configured Codec, which defaults to fncache.GobCodec for backward
+// compatibility.
 type RedisCache[FnParams comparable, FnReturns any] struct {
-	client *redis.Client
-	ttl    time.Duration
-	prefix string
+	client  *redis.Client
+	ttl     time.Duration
+	prefix  string
+	codec   fncache.Codec
+	bus     eventbus.EventBus
+	keyFunc fncache.KeyFunc[FnParams]
 }
 
-// NewRedisCache creates a RedisCache with the specified Redis options, TTL, and key prefix.
-func NewRedisCache[FnParams comparable, FnReturns any](opts *redis.Options, ttl time.Duration, prefix string) *RedisCache[FnParams, FnReturns] {
-	client := redis.NewClient(opts)
-	return &RedisCache[FnParams, FnReturns]{
-		client: client,
-		ttl:    ttl,
-		prefix: prefix,
+// Option configures a RedisCache constructed via NewRedisCache.
+type Option[FnParams comparable, FnReturns any] func(*RedisCache[FnParams, FnReturns])
+
+// WithCodec overrides the Codec used to serialize cached values. The
+// default is fncache.GobCodec.
+func WithCodec[FnParams comparable, FnReturns any](codec fncache.Codec) Option[FnParams, FnReturns] {
+	return func(r *RedisCache[FnParams, FnReturns]) {
+		r.codec = codec
 	}
 }
 
-// computeKey returns a Redis key with the configured prefix and a hex-encoded FNV hash of the params.
-func computeKey[FnParams comparable](prefix string, params FnParams) string {
-	hasher := fnv.New64a()
-	keyStr := fmt.Sprint(params)
-	hasher.Write([]byte(keyStr))
-	return fmt.Sprintf("%s:%s", prefix, hex.EncodeToString(hasher.Sum(nil)))
+// WithEventBus makes Set, Delete, and SetMulti publish a key invalidation
+// event to bus after a successful write, so other nodes (or local L1 tiers)
+// can evict their own copies.
+func WithEventBus[FnParams comparable, FnReturns any](bus eventbus.EventBus) Option[FnParams, FnReturns] {
+	return func(r *RedisCache[FnParams, FnReturns]) {
+		r.bus = bus
+	}
 }
 
-// encode serializes a value using gob.
-func encode[T any](v T) ([]byte, error) {
-	var buf bytes.Buffer
-	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
-		return nil, err
+// WithKeyFunc overrides how FnParams is turned into a Redis key (and, if an
+// EventBus is configured, an invalidation topic). The default is
+// fncache.KeyFuncFNVSprint, kept for backward compatibility.
+func WithKeyFunc[FnParams comparable, FnReturns any](fn fncache.KeyFunc[FnParams]) Option[FnParams, FnReturns] {
+	return func(r *RedisCache[FnParams, FnReturns]) {
+		r.keyFunc = fn
+	}
+}
+
+// NewRedisCache creates a RedisCache with the specified Redis options, TTL,
+// and key prefix. Use options to override defaults such as the Codec or
+// KeyFunc.
+func NewRedisCache[FnParams comparable, FnReturns any](opts *redis.Options, ttl time.Duration, prefix string, options ...Option[FnParams, FnReturns]) *RedisCache[FnParams, FnReturns] {
+	client := redis.NewClient(opts)
+	r := &RedisCache[FnParams, FnReturns]{
+		client:  client,
+		ttl:     ttl,
+		prefix:  prefix,
+		codec:   fncache.GobCodec{},
+		keyFunc: fncache.KeyFuncFNVSprint[FnParams],
 	}
-	return buf.Bytes(), nil
+	for _, option := range options {
+		option(r)
+	}
+	return r
 }
 
-// decode deserializes data into v using gob.
-func decode[T any](data []byte, v *T) error {
-	return gob.NewDecoder(bytes.NewBuffer(data)).Decode(v)
+// computeKey returns a Redis key with the configured prefix and the
+// configured KeyFunc's derivation of params.
+func (r *RedisCache[FnParams, FnReturns]) computeKey(params FnParams) string {
+	return fmt.Sprintf("%s:%s", r.prefix, r.keyFunc(params))
 }
 
 // Get retrieves the cached value for the given key.
 func (r *RedisCache[FnParams, FnReturns]) Get(ctx context.Context, params FnParams) (FnReturns, error) {
 	var zero FnReturns
-	key := computeKey(r.prefix, params)
+	key := r.computeKey(params)
 	data, err := r.client.Get(ctx, key).Bytes()
 	if err != nil {
 		return zero, err
 	}
 	var result FnReturns
-	if err := decode(data, &result); err != nil {
+	if err := r.codec.Unmarshal(data, &result); err != nil {
 		return zero, err
 	}
 	return result, nil
@@ -69,10 +92,100 @@ func (r *RedisCache[FnParams, FnReturns]) Get(ctx context.Context, params FnPara
 
 // Set stores the value for the given key with the cache TTL.
 func (r *RedisCache[FnParams, FnReturns]) Set(ctx context.Context, params FnParams, value FnReturns) error {
-	key := computeKey(r.prefix, params)
-	data, err := encode(value)
+	key := r.computeKey(params)
+	data, err := r.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := r.client.Set(ctx, key, data, r.ttl).Err(); err != nil {
+		return err
+	}
+	return r.publish(ctx, params)
+}
+
+// Delete removes the cached value for the given key.
+func (r *RedisCache[FnParams, FnReturns]) Delete(ctx context.Context, params FnParams) error {
+	key := r.computeKey(params)
+	if err := r.client.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return r.publish(ctx, params)
+}
+
+// publish announces that params has changed, if an EventBus is configured.
+// The event topic is derived with the same KeyFunc used for storage keys,
+// so subscribers using the same KeyFunc can match events to local entries.
+func (r *RedisCache[FnParams, FnReturns]) publish(ctx context.Context, params FnParams) error {
+	if r.bus == nil {
+		return nil
+	}
+	return r.bus.Publish(ctx, r.keyFunc(params))
+}
+
+// Exists reports whether a value is cached for the given key.
+func (r *RedisCache[FnParams, FnReturns]) Exists(ctx context.Context, params FnParams) (bool, error) {
+	key := r.computeKey(params)
+	n, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// GetMulti retrieves whichever of params are cached using a single Redis
+// pipeline, omitting misses rather than erroring on them.
+func (r *RedisCache[FnParams, FnReturns]) GetMulti(ctx context.Context, params []FnParams) (map[FnParams]FnReturns, error) {
+	results := make(map[FnParams]FnReturns, len(params))
+	if len(params) == 0 {
+		return results, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make(map[FnParams]*redis.StringCmd, len(params))
+	for _, p := range params {
+		cmds[p] = pipe.Get(ctx, r.computeKey(p))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	for p, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			continue
+		}
+		var value FnReturns
+		if err := r.codec.Unmarshal(data, &value); err != nil {
+			continue
+		}
+		results[p] = value
+	}
+	return results, nil
+}
+
+// SetMulti stores every key/value pair in values using a single Redis
+// pipeline.
+func (r *RedisCache[FnParams, FnReturns]) SetMulti(ctx context.Context, values map[FnParams]FnReturns) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for p, value := range values {
+		data, err := r.codec.Marshal(value)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, r.computeKey(p), data, r.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, data, r.ttl).Err()
+
+	for p := range values {
+		if err := r.publish(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/NodePath81/fncache"
 	"github.com/NodePath81/fncache/redis"
 	goredis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -121,6 +122,51 @@ func TestRedisCache(t *testing.T) {
 		assert.Equal(t, "value2", val2)
 	})
 
+	t.Run("Custom KeyFunc", func(t *testing.T) {
+		cache := redis.NewRedisCache[string, string](redisOpts, time.Minute, "custom-keyfunc",
+			redis.WithKeyFunc[string, string](fncache.KeyFuncJSONSHA256[string]))
+
+		err := cache.Set(ctx, "custom-key", "custom-value")
+		require.NoError(t, err)
+
+		val, err := cache.Get(ctx, "custom-key")
+		require.NoError(t, err)
+		assert.Equal(t, "custom-value", val)
+	})
+
+	t.Run("Delete and Exists", func(t *testing.T) {
+		cache := redis.NewRedisCache[string, string](redisOpts, time.Minute, "test")
+
+		err := cache.Set(ctx, "to-delete", "value")
+		require.NoError(t, err)
+
+		ok, err := cache.Exists(ctx, "to-delete")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		err = cache.Delete(ctx, "to-delete")
+		require.NoError(t, err)
+
+		ok, err = cache.Exists(ctx, "to-delete")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("GetMulti and SetMulti", func(t *testing.T) {
+		cache := redis.NewRedisCache[string, int](redisOpts, time.Minute, "multi")
+
+		err := cache.SetMulti(ctx, map[string]int{"a": 1, "b": 2})
+		require.NoError(t, err)
+
+		got, err := cache.GetMulti(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, 1, got["a"])
+		assert.Equal(t, 2, got["b"])
+		_, ok := got["c"]
+		assert.False(t, ok)
+	})
+
 	t.Run("Encoding Error", func(t *testing.T) {
 		// Create a type that can't be gob encoded
 		type UnencodableType struct {
@@ -0,0 +1,243 @@
+// Package tiered composes a fast local CacheLayer in front of a slower one
+// into a single CacheLayer, giving callers the standard L1 (in-memory) + L2
+// (Redis) caching topology.
+package tiered
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/NodePath81/fncache"
+)
+
+// ErrNegative is returned by Get when params is within its negative-result
+// TTL, i.e. a prior L2 lookup is known to have missed recently.
+var ErrNegative = errors.New("tiered: negative cache hit")
+
+// PromotionPolicy controls when an L2 hit is copied up into L1.
+type PromotionPolicy int
+
+const (
+	// PromoteAlways copies every L2 hit into L1 immediately. This is the
+	// default.
+	PromoteAlways PromotionPolicy = iota
+	// PromoteOnSecondHit only copies a key into L1 once it has missed L1
+	// and hit L2 twice, so one-off reads don't evict hotter L1 entries.
+	PromoteOnSecondHit
+)
+
+// TieredCache implements fncache.CacheLayer by composing a fast local L1
+// layer (typically memory.InMemoryCache) in front of a slower L2 layer
+// (typically redis.RedisCache). Get checks L1 first, falling through to L2
+// and promoting into L1 according to the configured PromotionPolicy. Set
+// writes through to both tiers.
+//
+// L1 and L2 should typically be constructed with L1's TTL shorter than
+// L2's, so L1 acts as a short-lived hot cache over the longer-lived L2.
+// Cross-node consistency between multiple TieredCache instances can be
+// layered on by constructing L1 and L2 with a shared eventbus.EventBus: the
+// redis package's RedisCache publishes on Set/Delete and the memory
+// package's InMemoryCache subscribes to evict, so no extra wiring is
+// needed here.
+type TieredCache[FnParams comparable, FnReturns any] struct {
+	l1 fncache.CacheLayer[FnParams, FnReturns]
+	l2 fncache.CacheLayer[FnParams, FnReturns]
+
+	promotion   PromotionPolicy
+	negativeTTL time.Duration
+
+	hitCounts sync.Map // FnParams -> int, used by PromoteOnSecondHit
+	negative  sync.Map // FnParams -> time.Time, used for negative caching
+}
+
+// Option configures a TieredCache constructed via NewTieredCache.
+type Option[FnParams comparable, FnReturns any] func(*TieredCache[FnParams, FnReturns])
+
+// WithPromotionPolicy overrides when an L2 hit is promoted into L1. The
+// default is PromoteAlways.
+func WithPromotionPolicy[FnParams comparable, FnReturns any](policy PromotionPolicy) Option[FnParams, FnReturns] {
+	return func(c *TieredCache[FnParams, FnReturns]) {
+		c.promotion = policy
+	}
+}
+
+// WithNegativeTTL enables negative caching: after an L2 miss, Get returns
+// ErrNegative for the same params for ttl without querying L2 again. It is
+// disabled (zero) by default.
+func WithNegativeTTL[FnParams comparable, FnReturns any](ttl time.Duration) Option[FnParams, FnReturns] {
+	return func(c *TieredCache[FnParams, FnReturns]) {
+		c.negativeTTL = ttl
+	}
+}
+
+// NewTieredCache creates a TieredCache over the given L1 and L2 layers.
+func NewTieredCache[FnParams comparable, FnReturns any](l1, l2 fncache.CacheLayer[FnParams, FnReturns], options ...Option[FnParams, FnReturns]) *TieredCache[FnParams, FnReturns] {
+	c := &TieredCache[FnParams, FnReturns]{
+		l1: l1,
+		l2: l2,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Get checks L1, then L2, promoting according to the configured
+// PromotionPolicy on an L2 hit.
+func (c *TieredCache[FnParams, FnReturns]) Get(ctx context.Context, params FnParams) (FnReturns, error) {
+	var zero FnReturns
+
+	if c.isNegative(params) {
+		return zero, ErrNegative
+	}
+
+	if value, err := c.l1.Get(ctx, params); err == nil {
+		return value, nil
+	}
+
+	value, err := c.l2.Get(ctx, params)
+	if err != nil {
+		c.markNegative(params)
+		return zero, err
+	}
+	c.clearNegative(params)
+
+	if c.shouldPromote(params) {
+		_ = c.l1.Set(ctx, params, value)
+	}
+	return value, nil
+}
+
+// Set writes value to both L2 and L1.
+func (c *TieredCache[FnParams, FnReturns]) Set(ctx context.Context, params FnParams, value FnReturns) error {
+	c.clearNegative(params)
+	if err := c.l2.Set(ctx, params, value); err != nil {
+		return err
+	}
+	return c.l1.Set(ctx, params, value)
+}
+
+// Delete removes params from both L1 and L2.
+func (c *TieredCache[FnParams, FnReturns]) Delete(ctx context.Context, params FnParams) error {
+	c.clearNegative(params)
+	if err := c.l2.Delete(ctx, params); err != nil {
+		return err
+	}
+	return c.l1.Delete(ctx, params)
+}
+
+// Exists reports whether params is cached in either tier.
+func (c *TieredCache[FnParams, FnReturns]) Exists(ctx context.Context, params FnParams) (bool, error) {
+	if ok, err := c.l1.Exists(ctx, params); err == nil && ok {
+		return true, nil
+	}
+	return c.l2.Exists(ctx, params)
+}
+
+// GetMulti resolves params from L1, falling through to L2 for misses and
+// promoting L2 hits according to the configured PromotionPolicy. Like Get,
+// a param still within its negative-result TTL is skipped rather than
+// re-queried against L2, and a fresh L2 miss starts its own negative-result
+// TTL; a negatively-cached param is simply omitted from the result map,
+// since GetMulti has no per-key error to report it through.
+func (c *TieredCache[FnParams, FnReturns]) GetMulti(ctx context.Context, paramsList []FnParams) (map[FnParams]FnReturns, error) {
+	results, err := c.l1.GetMulti(ctx, paramsList)
+	if err != nil {
+		results = make(map[FnParams]FnReturns)
+	}
+
+	var misses []FnParams
+	for _, params := range paramsList {
+		if _, ok := results[params]; ok {
+			continue
+		}
+		if c.isNegative(params) {
+			continue
+		}
+		misses = append(misses, params)
+	}
+	if len(misses) == 0 {
+		return results, nil
+	}
+
+	l2Hits, err := c.l2.GetMulti(ctx, misses)
+	if err != nil {
+		return results, err
+	}
+
+	promoted := make(map[FnParams]FnReturns, len(l2Hits))
+	for _, params := range misses {
+		value, ok := l2Hits[params]
+		if !ok {
+			c.markNegative(params)
+			continue
+		}
+		c.clearNegative(params)
+		results[params] = value
+		if c.shouldPromote(params) {
+			promoted[params] = value
+		}
+	}
+	if len(promoted) > 0 {
+		_ = c.l1.SetMulti(ctx, promoted)
+	}
+	return results, nil
+}
+
+// SetMulti writes every key/value pair to both L2 and L1.
+func (c *TieredCache[FnParams, FnReturns]) SetMulti(ctx context.Context, values map[FnParams]FnReturns) error {
+	if err := c.l2.SetMulti(ctx, values); err != nil {
+		return err
+	}
+	return c.l1.SetMulti(ctx, values)
+}
+
+func (c *TieredCache[FnParams, FnReturns]) shouldPromote(params FnParams) bool {
+	if c.promotion != PromoteOnSecondHit {
+		return true
+	}
+
+	v, loaded := c.hitCounts.LoadOrStore(params, 1)
+	if !loaded {
+		return false
+	}
+	count := v.(int) + 1
+	if count >= 2 {
+		c.hitCounts.Delete(params)
+		return true
+	}
+	c.hitCounts.Store(params, count)
+	return false
+}
+
+func (c *TieredCache[FnParams, FnReturns]) isNegative(params FnParams) bool {
+	if c.negativeTTL <= 0 {
+		return false
+	}
+	v, ok := c.negative.Load(params)
+	if !ok {
+		return false
+	}
+	until := v.(time.Time)
+	if time.Now().After(until) {
+		c.negative.Delete(params)
+		return false
+	}
+	return true
+}
+
+func (c *TieredCache[FnParams, FnReturns]) markNegative(params FnParams) {
+	if c.negativeTTL <= 0 {
+		return
+	}
+	c.negative.Store(params, time.Now().Add(c.negativeTTL))
+}
+
+func (c *TieredCache[FnParams, FnReturns]) clearNegative(params FnParams) {
+	if c.negativeTTL <= 0 {
+		return
+	}
+	c.negative.Delete(params)
+}
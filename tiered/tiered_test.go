@@ -0,0 +1,201 @@
+package tiered_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NodePath81/fncache/tiered"
+)
+
+type mockLayer[K comparable, V any] struct {
+	store  map[K]V
+	getCnt int
+	setCnt int
+
+	// getMultiKeyCnt counts, per key, how many GetMulti calls queried it.
+	getMultiKeyCnt map[K]int
+}
+
+func (m *mockLayer[K, V]) Get(ctx context.Context, key K) (V, error) {
+	m.getCnt++
+	val, ok := m.store[key]
+	if !ok {
+		var zero V
+		return zero, errors.New("not found")
+	}
+	return val, nil
+}
+
+func (m *mockLayer[K, V]) Set(ctx context.Context, key K, value V) error {
+	m.setCnt++
+	if m.store == nil {
+		m.store = make(map[K]V)
+	}
+	m.store[key] = value
+	return nil
+}
+
+func (m *mockLayer[K, V]) Delete(ctx context.Context, key K) error {
+	delete(m.store, key)
+	return nil
+}
+
+func (m *mockLayer[K, V]) Exists(ctx context.Context, key K) (bool, error) {
+	_, ok := m.store[key]
+	return ok, nil
+}
+
+func (m *mockLayer[K, V]) GetMulti(ctx context.Context, keys []K) (map[K]V, error) {
+	if m.getMultiKeyCnt == nil {
+		m.getMultiKeyCnt = make(map[K]int)
+	}
+	results := make(map[K]V)
+	for _, key := range keys {
+		m.getMultiKeyCnt[key]++
+		if val, ok := m.store[key]; ok {
+			results[key] = val
+		}
+	}
+	return results, nil
+}
+
+func (m *mockLayer[K, V]) SetMulti(ctx context.Context, values map[K]V) error {
+	if m.store == nil {
+		m.store = make(map[K]V)
+	}
+	for key, value := range values {
+		m.store[key] = value
+	}
+	return nil
+}
+
+func TestTieredCache_GetPromotesFromL2(t *testing.T) {
+	ctx := context.Background()
+	l1 := &mockLayer[string, int]{}
+	l2 := &mockLayer[string, int]{store: map[string]int{"a": 1}}
+
+	cache := tiered.NewTieredCache[string, int](l1, l2)
+
+	val, err := cache.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val != 1 {
+		t.Errorf("Expected 1, got %d", val)
+	}
+	if l1.setCnt != 1 {
+		t.Errorf("Expected L2 hit to promote into L1, got setCnt %d", l1.setCnt)
+	}
+
+	// Second Get should hit L1 and not touch L2 again.
+	l2GetsBefore := l2.getCnt
+	if _, err := cache.Get(ctx, "a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l2.getCnt != l2GetsBefore {
+		t.Errorf("Expected L2 not to be queried again, getCnt went from %d to %d", l2GetsBefore, l2.getCnt)
+	}
+}
+
+func TestTieredCache_PromoteOnSecondHit(t *testing.T) {
+	ctx := context.Background()
+	l1 := &mockLayer[string, int]{}
+	l2 := &mockLayer[string, int]{store: map[string]int{"a": 1}}
+
+	cache := tiered.NewTieredCache[string, int](l1, l2, tiered.WithPromotionPolicy[string, int](tiered.PromoteOnSecondHit))
+
+	if _, err := cache.Get(ctx, "a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l1.setCnt != 0 {
+		t.Errorf("Expected first L2 hit not to promote, got setCnt %d", l1.setCnt)
+	}
+
+	if _, err := cache.Get(ctx, "a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l1.setCnt != 1 {
+		t.Errorf("Expected second L2 hit to promote, got setCnt %d", l1.setCnt)
+	}
+}
+
+func TestTieredCache_SetWritesThroughBothTiers(t *testing.T) {
+	ctx := context.Background()
+	l1 := &mockLayer[string, int]{}
+	l2 := &mockLayer[string, int]{}
+
+	cache := tiered.NewTieredCache[string, int](l1, l2)
+
+	if err := cache.Set(ctx, "a", 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l1.store["a"] != 5 || l2.store["a"] != 5 {
+		t.Errorf("Expected both tiers to hold the value, l1=%v l2=%v", l1.store, l2.store)
+	}
+}
+
+func TestTieredCache_NegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	l1 := &mockLayer[string, int]{}
+	l2 := &mockLayer[string, int]{}
+
+	cache := tiered.NewTieredCache[string, int](l1, l2, tiered.WithNegativeTTL[string, int](50*time.Millisecond))
+
+	if _, err := cache.Get(ctx, "missing"); err == nil {
+		t.Fatal("Expected an error for a missing key")
+	}
+	l2GetsAfterFirstMiss := l2.getCnt
+
+	if _, err := cache.Get(ctx, "missing"); !errors.Is(err, tiered.ErrNegative) {
+		t.Errorf("Expected ErrNegative, got %v", err)
+	}
+	if l2.getCnt != l2GetsAfterFirstMiss {
+		t.Errorf("Expected L2 not to be queried while negative cache is active")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := cache.Get(ctx, "missing"); err == nil || errors.Is(err, tiered.ErrNegative) {
+		t.Errorf("Expected negative cache to expire and retry L2, got %v", err)
+	}
+	if l2.getCnt != l2GetsAfterFirstMiss+1 {
+		t.Errorf("Expected L2 to be queried again after negative TTL expired")
+	}
+}
+
+func TestTieredCache_GetMultiRespectsNegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	l1 := &mockLayer[string, int]{}
+	l2 := &mockLayer[string, int]{store: map[string]int{"present": 1}}
+
+	cache := tiered.NewTieredCache[string, int](l1, l2, tiered.WithNegativeTTL[string, int](50*time.Millisecond))
+
+	results, err := cache.GetMulti(ctx, []string{"present", "missing"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 1 || results["present"] != 1 {
+		t.Errorf("Expected only 'present' in results, got %+v", results)
+	}
+	if l2.getMultiKeyCnt["missing"] != 1 {
+		t.Fatalf("Expected L2 to be queried once for 'missing', got %d", l2.getMultiKeyCnt["missing"])
+	}
+
+	// "missing" is now within its negative-result TTL: a second GetMulti
+	// must not re-query L2 for it, matching Get's behavior.
+	if _, err := cache.GetMulti(ctx, []string{"present", "missing"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l2.getMultiKeyCnt["missing"] != 1 {
+		t.Errorf("Expected L2 not to be re-queried for 'missing' while negative cache is active, got %d calls", l2.getMultiKeyCnt["missing"])
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := cache.GetMulti(ctx, []string{"missing"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l2.getMultiKeyCnt["missing"] != 2 {
+		t.Errorf("Expected L2 to be queried again for 'missing' after negative TTL expired, got %d calls", l2.getMultiKeyCnt["missing"])
+	}
+}